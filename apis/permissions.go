@@ -10,16 +10,33 @@ import (
 
 	"github.com/samber/lo"
 	"go.opentelemetry.io/otel"
+
+	"github.com/sboleyn/dashboard-aggregator/cache"
 )
 
 type PermissionsAPI struct {
 	permissionsURL *url.URL
+	cache          *cache.PermissionsCache
+}
+
+type PermissionsAPIOption func(*PermissionsAPI)
+
+// WithPermissionsCache caches GetPublicIDS results per group so that a
+// thundering herd of dashboard loads collapses into one HTTP call.
+func WithPermissionsCache(c *cache.PermissionsCache) PermissionsAPIOption {
+	return func(p *PermissionsAPI) {
+		p.cache = c
+	}
 }
 
-func NewPermissionsAPI(permissionsURL *url.URL) *PermissionsAPI {
-	return &PermissionsAPI{
+func NewPermissionsAPI(permissionsURL *url.URL, opts ...PermissionsAPIOption) *PermissionsAPI {
+	p := &PermissionsAPI{
 		permissionsURL: permissionsURL,
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
 type Permission struct {
@@ -33,6 +50,16 @@ func (p *PermissionsAPI) GetPublicIDS(ctx context.Context, publicGroup string) (
 	ctx, span := otel.Tracer(otelName).Start(ctx, "GetPublicIDS")
 	defer span.End()
 
+	fetch := p.fetchPublicIDS
+	if p.cache != nil {
+		return p.cache.GetOrFetch(ctx, publicGroup, func(ctx context.Context) ([]string, error) {
+			return fetch(ctx, publicGroup)
+		})
+	}
+	return fetch(ctx, publicGroup)
+}
+
+func (p *PermissionsAPI) fetchPublicIDS(ctx context.Context, publicGroup string) ([]string, error) {
 	fullURL := *p.permissionsURL
 	fullURL = *fullURL.JoinPath("permissions", "abbreviated", "subjects", "group", publicGroup, "app")
 
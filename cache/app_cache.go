@@ -0,0 +1,57 @@
+// Package cache provides in-process, TTL-bounded caches for app lookups and
+// the permissions service's public-group ID list, so repeated dashboard
+// loads avoid redundant database and HTTP round-trips.
+package cache
+
+import (
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/sboleyn/dashboard-aggregator/db"
+)
+
+type appEntry struct {
+	app       db.App
+	expiresAt time.Time
+}
+
+// AppCache is an LRU, TTL-bounded cache of apps keyed by ID. It implements
+// db.Cache so it can be wired into ListApps via db.WithCache.
+type AppCache struct {
+	ttl time.Duration
+	lru *lru.Cache[string, appEntry]
+}
+
+// NewAppCache builds an AppCache holding at most size entries, each valid
+// for ttl after being set.
+func NewAppCache(size int, ttl time.Duration) (*AppCache, error) {
+	l, err := lru.New[string, appEntry](size)
+	if err != nil {
+		return nil, err
+	}
+	return &AppCache{ttl: ttl, lru: l}, nil
+}
+
+func (c *AppCache) BulkGet(ids []string) (hits map[string]db.App, missing []string) {
+	hits = make(map[string]db.App, len(ids))
+	now := time.Now()
+	for _, id := range ids {
+		entry, ok := c.lru.Get(id)
+		if !ok || now.After(entry.expiresAt) {
+			missing = append(missing, id)
+			continue
+		}
+		hits[id] = entry.app
+	}
+	return hits, missing
+}
+
+func (c *AppCache) BulkSet(apps map[string]db.App) {
+	expiresAt := time.Now().Add(c.ttl)
+	for id, app := range apps {
+		c.lru.Add(id, appEntry{app: app, expiresAt: expiresAt})
+	}
+}
+
+var _ db.Cache = (*AppCache)(nil)
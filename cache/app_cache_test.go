@@ -0,0 +1,40 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sboleyn/dashboard-aggregator/cache"
+	"github.com/sboleyn/dashboard-aggregator/db"
+)
+
+func TestAppCache_BulkGetReportsMissingAndHits(t *testing.T) {
+	c, err := cache.NewAppCache(10, time.Minute)
+	if err != nil {
+		t.Fatalf("NewAppCache returned error: %v", err)
+	}
+
+	c.BulkSet(map[string]db.App{"app-1": {ID: "app-1", Name: "Widget Tracker"}})
+
+	hits, missing := c.BulkGet([]string{"app-1", "app-2"})
+	if len(hits) != 1 || hits["app-1"].Name != "Widget Tracker" {
+		t.Fatalf("expected app-1 to be a hit, got %+v", hits)
+	}
+	if len(missing) != 1 || missing[0] != "app-2" {
+		t.Fatalf("expected app-2 to be missing, got %+v", missing)
+	}
+}
+
+func TestAppCache_EntriesExpireAfterTTL(t *testing.T) {
+	c, err := cache.NewAppCache(10, -time.Second)
+	if err != nil {
+		t.Fatalf("NewAppCache returned error: %v", err)
+	}
+
+	c.BulkSet(map[string]db.App{"app-1": {ID: "app-1"}})
+
+	_, missing := c.BulkGet([]string{"app-1"})
+	if len(missing) != 1 {
+		t.Fatalf("expected expired entry to be reported missing, got %+v", missing)
+	}
+}
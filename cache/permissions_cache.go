@@ -0,0 +1,76 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+type permissionsEntry struct {
+	ids       []string
+	expiresAt time.Time
+}
+
+// PermissionsCache caches the public-group ID list PermissionsAPI fetches
+// over HTTP. A singleflight.Group collapses concurrent misses for the same
+// group into a single in-flight fetch.
+type PermissionsCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]permissionsEntry
+
+	group singleflight.Group
+}
+
+// NewPermissionsCache builds a PermissionsCache whose entries are valid for
+// ttl after being fetched.
+func NewPermissionsCache(ttl time.Duration) *PermissionsCache {
+	return &PermissionsCache{
+		ttl:     ttl,
+		entries: make(map[string]permissionsEntry),
+	}
+}
+
+// GetOrFetch returns the cached ID list for group if it's still fresh,
+// otherwise calls fetch, caching and returning its result. Concurrent calls
+// for the same group share a single fetch.
+func (c *PermissionsCache) GetOrFetch(ctx context.Context, group string, fetch func(ctx context.Context) ([]string, error)) ([]string, error) {
+	if ids, ok := c.get(group); ok {
+		return ids, nil
+	}
+
+	v, err, _ := c.group.Do(group, func() (interface{}, error) {
+		if ids, ok := c.get(group); ok {
+			return ids, nil
+		}
+		ids, err := fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.set(group, ids)
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]string), nil
+}
+
+func (c *PermissionsCache) get(group string) ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[group]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.ids, true
+}
+
+func (c *PermissionsCache) set(group string, ids []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[group] = permissionsEntry{ids: ids, expiresAt: time.Now().Add(c.ttl)}
+}
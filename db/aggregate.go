@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/sync/errgroup"
+)
+
+// DashboardResult bundles every section of the dashboard, as aggregated by
+// AggregateDashboard.
+type DashboardResult struct {
+	PopularFeatured []App
+	Public          []App
+	RecentlyAdded   []App
+	RecentlyUsed    []App
+}
+
+// AggregateDashboard fetches every dashboard section concurrently via
+// errgroup, cancelling the remaining in-flight queries as soon as one
+// errors, and records each section's duration as a span attribute. Use
+// WithConcurrencyLimit to bound how many sections run at once; callers that
+// need per-section options (WithCache, WithTx, ...) should pass them via
+// opts, which apply to every section.
+//
+// It takes a Store rather than being a *Database method so its
+// cancellation/error-propagation behavior can be exercised against
+// dbmem.Memory in tests.
+func AggregateDashboard(ctx context.Context, store Store, cfg *AppsQueryConfig, opts ...QueryOption) (*DashboardResult, error) {
+	ctx, span := otel.Tracer(otelName).Start(ctx, "AggregateDashboard")
+	defer span.End()
+
+	querySettings := &QuerySettings{}
+	for _, opt := range opts {
+		opt(querySettings)
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	if hasLimit, limit := querySettings.ConcurrencyLimit(); hasLimit {
+		g.SetLimit(limit)
+	}
+
+	result := &DashboardResult{}
+
+	fetch := func(name string, dest *[]App, fn func() ([]App, error)) {
+		g.Go(func() error {
+			start := time.Now()
+			apps, err := fn()
+			span.SetAttributes(attribute.Int64(name+"_ms", time.Since(start).Milliseconds()))
+			if err != nil {
+				return err
+			}
+			*dest = apps
+			return nil
+		})
+	}
+
+	fetch("popular_featured", &result.PopularFeatured, func() ([]App, error) {
+		return store.PopularFeaturedApps(ctx, cfg, opts...)
+	})
+	fetch("public", &result.Public, func() ([]App, error) {
+		return store.PublicAppsQuery(ctx, cfg.Username, cfg.GroupsIndex, cfg.AppIDs, opts...)
+	})
+	fetch("recently_added", &result.RecentlyAdded, func() ([]App, error) {
+		return store.RecentlyAddedApps(ctx, cfg, opts...)
+	})
+	fetch("recently_used", &result.RecentlyUsed, func() ([]App, error) {
+		return store.RecentlyUsedApps(ctx, cfg, opts...)
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,66 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sboleyn/dashboard-aggregator/db"
+	"github.com/sboleyn/dashboard-aggregator/db/dbmem"
+)
+
+// blockingRecentlyUsedStore wraps a db.Store, blocking in RecentlyUsedApps
+// until ctx is cancelled (reporting whether that happened on cancelled) or a
+// timeout elapses, so tests can observe that AggregateDashboard's errgroup
+// actually cancels sibling sections rather than letting them run to
+// completion after one errors.
+type blockingRecentlyUsedStore struct {
+	db.Store
+	cancelled chan bool
+}
+
+func (s blockingRecentlyUsedStore) RecentlyUsedApps(ctx context.Context, cfg *db.AppsQueryConfig, opts ...db.QueryOption) ([]db.App, error) {
+	select {
+	case <-ctx.Done():
+		s.cancelled <- true
+	case <-time.After(5 * time.Second):
+		s.cancelled <- false
+	}
+	return nil, nil
+}
+
+func TestAggregateDashboard_ErrorCancelsOtherSections(t *testing.T) {
+	wantErr := errors.New("popular featured apps boom")
+
+	store := blockingRecentlyUsedStore{
+		Store:     erroringPopularFeaturedStore{Store: dbmem.New(), err: wantErr},
+		cancelled: make(chan bool, 1),
+	}
+
+	_, err := db.AggregateDashboard(context.Background(), store, &db.AppsQueryConfig{})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected %v, got %v", wantErr, err)
+	}
+
+	select {
+	case cancelled := <-store.cancelled:
+		if !cancelled {
+			t.Fatalf("RecentlyUsedApps ran to completion instead of observing ctx cancellation")
+		}
+	case <-time.After(6 * time.Second):
+		t.Fatalf("RecentlyUsedApps never returned")
+	}
+}
+
+// erroringPopularFeaturedStore wraps a db.Store, making PopularFeaturedApps
+// fail immediately so AggregateDashboard's errgroup has a section error to
+// propagate and cancel on.
+type erroringPopularFeaturedStore struct {
+	db.Store
+	err error
+}
+
+func (s erroringPopularFeaturedStore) PopularFeaturedApps(ctx context.Context, cfg *db.AppsQueryConfig, opts ...db.QueryOption) ([]db.App, error) {
+	return nil, s.err
+}
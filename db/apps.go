@@ -3,8 +3,11 @@ package db
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/lib/pq"
 	"go.opentelemetry.io/otel"
 )
@@ -14,10 +17,90 @@ type AppsQueryConfig struct {
 	GroupsIndex       int
 	AppIDs            []string
 	StartDateInterval string
+
+	// SearchRanking, when set, narrows and orders results to the apps
+	// named by an external search engine (see the search package),
+	// overriding the function's default SortBy. PopularFeaturedApps and
+	// RecentlyAddedApps honor it; RecentlyUsedApps ignores it.
+	SearchRanking []SearchRanking
 }
 
-func (d *Database) PopularFeaturedApps(ctx context.Context, cfg *AppsQueryConfig, opts ...QueryOption) ([]App, error) {
-	ctx, span := otel.Tracer(otelName).Start(ctx, "PopularFeaturedApps")
+// SearchRanking is one app's relevance score as returned by an external
+// search engine. It's the db package's view of search.AppHit, kept
+// independent of the search package to avoid an import cycle.
+type SearchRanking struct {
+	AppID string
+	Score float64
+}
+
+// SortField selects which computed/aggregate column ListApps orders by.
+type SortField int
+
+const (
+	SortByIntegrationDate SortField = iota
+	SortByJobCount
+	SortByMostRecentStart
+	SortByName
+)
+
+type SortDirection int
+
+const (
+	Asc SortDirection = iota
+	Desc
+)
+
+type SortBy struct {
+	Field     SortField
+	Direction SortDirection
+}
+
+// AppFilter describes the criteria a ListApps call is narrowed by. Every
+// field is optional; the zero value matches every non-deleted, non-disabled
+// app that has an integration date. Fields that require joining additional tables (favorites, jobs,
+// categories) only add those joins when set, so callers that don't need them
+// don't pay for them.
+type AppFilter struct {
+	NameMatches        string
+	DescriptionMatches string
+	IntegratorUsername string
+	AppIDs             []string
+	CategoryIDs        []int
+
+	IntegrationDateFrom *time.Time
+	IntegrationDateTo   *time.Time
+	JobStartedFrom      *time.Time
+	JobStartedTo        *time.Time
+
+	// JobStartedWithin, when set, restricts to jobs with start_date newer
+	// than now() - JobStartedWithin (a Postgres interval literal, e.g.
+	// "30 days"). It composes with JobStartedFrom/To.
+	JobStartedWithin string
+
+	// RanByUsername, when set, restricts to jobs run by this user (join
+	// through jobs.user_id -> users.username).
+	RanByUsername string
+
+	// OnlyFavorites restricts results to apps in FavoritesUsername's
+	// favorite category tree. FavoritesUsername/FavoritesGroupIndex are
+	// also used, independent of OnlyFavorites, to compute the is_favorite
+	// column whenever FavoritesUsername is set.
+	OnlyFavorites       bool
+	FavoritesUsername   string
+	FavoritesGroupIndex int
+
+	// OnlyPublic marks every matched row as public (is_public = true)
+	// instead of computing is_public by membership in PublicAppIDs.
+	OnlyPublic   bool
+	PublicAppIDs []string
+
+	// SearchRanking, when set, narrows results to apps present in the
+	// ranking and orders them by descending score, overriding sort.
+	SearchRanking []SearchRanking
+}
+
+func (d *Database) ListApps(ctx context.Context, filter *AppFilter, sort SortBy, opts ...QueryOption) ([]App, error) {
+	ctx, span := otel.Tracer(otelName).Start(ctx, "ListApps")
 	defer span.End()
 
 	var (
@@ -37,67 +120,199 @@ func (d *Database) PopularFeaturedApps(ctx context.Context, cfg *AppsQueryConfig
 		db = d.goquDB
 	}
 
+	// Cached App rows never carry IsFavorite/IsPublic: those are
+	// per-caller (favorites are scoped to FavoritesUsername, and the same
+	// cached AppIDs set is shared by callers filtering different users),
+	// so they're recomputed after every cache read via favoriteAppIDs and
+	// applyPerCallerFields rather than trusted from the cache.
+	var (
+		cache         Cache
+		cacheHits     map[string]App
+		missingAppIDs []string
+	)
+	if c := querySettings.Cache(); c != nil && len(filter.AppIDs) > 0 {
+		hits, missing := c.BulkGet(filter.AppIDs)
+		if len(missing) == 0 {
+			apps = make([]App, 0, len(filter.AppIDs))
+			for _, id := range filter.AppIDs {
+				if a, ok := hits[id]; ok {
+					apps = append(apps, a)
+				}
+			}
+			favorites, favErr := d.favoriteAppIDs(ctx, db, filter.FavoritesUsername, filter.FavoritesGroupIndex)
+			if favErr != nil {
+				return nil, favErr
+			}
+			apps = applyPerCallerFields(apps, filter, favorites)
+			return sortAndPage(apps, sort, filter.SearchRanking, querySettings), nil
+		}
+		cache, cacheHits, missingAppIDs = c, hits, missing
+	}
+
 	a := goqu.T("app_listing")
 	j := goqu.T("jobs")
-	u := goqu.T("users")
-	w := goqu.T("workspace")
-	acg := goqu.T("app_category_group")
-	aca := goqu.T("app_category_app")
 
-	subquery := db.From(u).
-		Join(w, goqu.On(u.Col("id").Eq(w.Col("user_id")))).
-		Join(acg, goqu.On(w.Col("root_category_id").Eq(acg.Col("parent_category_id")))).
-		Join(aca, goqu.On(acg.Col("child_category_id").Eq(aca.Col("app_category_id")))).
-		Where(
-			u.Col("username").Eq(cfg.Username),
-			acg.Col("child_index").Eq(cfg.GroupsIndex),
-			aca.Col("app_id").Eq(a.Col("id")),
-		)
+	needsJobJoin := sort.Field == SortByJobCount || sort.Field == SortByMostRecentStart ||
+		filter.JobStartedFrom != nil || filter.JobStartedTo != nil || filter.JobStartedWithin != "" ||
+		filter.RanByUsername != ""
+	needsCategoryJoin := len(filter.CategoryIDs) > 0
+
+	isFavoriteExpr := goqu.L("false")
+	if filter.FavoritesUsername != "" {
+		u := goqu.T("users")
+		w := goqu.T("workspace")
+		acg := goqu.T("app_category_group")
+		aca := goqu.T("app_category_app")
+
+		favoritesSubquery := db.From(u).
+			Join(w, goqu.On(u.Col("id").Eq(w.Col("user_id")))).
+			Join(acg, goqu.On(w.Col("root_category_id").Eq(acg.Col("parent_category_id")))).
+			Join(aca, goqu.On(acg.Col("child_category_id").Eq(aca.Col("app_category_id")))).
+			Where(
+				u.Col("username").Eq(filter.FavoritesUsername),
+				acg.Col("child_index").Eq(filter.FavoritesGroupIndex),
+				aca.Col("app_id").Eq(a.Col("id")),
+			)
+		isFavoriteExpr = goqu.L("EXISTS(?)", favoritesSubquery)
+	}
+
+	var isPublicExpr goqu.Expression
+	switch {
+	case filter.OnlyPublic:
+		isPublicExpr = goqu.L("true")
+	case len(filter.PublicAppIDs) > 0:
+		isPublicExpr = a.Col("id").Eq(goqu.Any(pq.Array(filter.PublicAppIDs)))
+	default:
+		isPublicExpr = goqu.L("false")
+	}
 
 	query := db.From(a).
 		Select(
 			a.Col("id"),
-			goqu.L(`'de'`).As("system_id"),
+			goqu.L(`'de'`).As(goqu.C("system_id")),
 			a.Col("name"),
 			a.Col("description"),
 			a.Col("wiki_url"),
 			a.Col("integration_date"),
 			a.Col("edited_date"),
 			a.Col("integrator_username").As(goqu.C("username")),
-			goqu.COUNT(j.Col("id")).As(goqu.C("job_count")),
-			goqu.L("EXISTS(?)", subquery).As(goqu.C("is_favorite")),
-			goqu.L("true").As(goqu.C("is_public")),
+			isFavoriteExpr.As(goqu.C("is_favorite")),
+			isPublicExpr.As(goqu.C("is_public")),
 		).
-		LeftJoin(j, goqu.On(j.Col("app_id").Eq(goqu.Cast(a.Col("id"), "TEXT")))).
 		Where(
-			a.Col("id").Eq(goqu.Any(pq.Array(cfg.AppIDs))),
-			a.Col("deleted").Eq(goqu.L("false")),
-			a.Col("disabled").Eq(goqu.L("false")),
+			a.Col("deleted").IsFalse(),
+			a.Col("disabled").IsFalse(),
 			a.Col("integration_date").IsNotNull(),
-			goqu.Or(
-				j.Col("start_date").Gte(goqu.L("now() - ?", goqu.Cast(goqu.L(fmt.Sprintf("'%s'", cfg.StartDateInterval)), "interval"))),
-				j.Col("start_date").IsNull(),
-			),
-		).
-		GroupBy(
-			a.Col("id"),
-			a.Col("name"),
-			a.Col("description"),
-			a.Col("wiki_url"),
-			a.Col("integration_date"),
-			a.Col("edited_date"),
-			a.Col("integrator_username"),
-		).
-		Order(
-			goqu.C("job_count").Desc(),
 		)
 
-	if querySettings.hasLimit {
-		query = query.Limit(querySettings.limit)
+	if needsJobJoin {
+		query = query.
+			SelectAppend(goqu.COUNT(j.Col("id")).As(goqu.C("job_count"))).
+			SelectAppend(goqu.MAX(j.Col("start_date")).As(goqu.C("most_recent_start_date"))).
+			LeftJoin(j, goqu.On(j.Col("app_id").Eq(goqu.Cast(a.Col("id"), "TEXT")))).
+			GroupBy(
+				a.Col("id"),
+				a.Col("name"),
+				a.Col("description"),
+				a.Col("wiki_url"),
+				a.Col("integration_date"),
+				a.Col("edited_date"),
+				a.Col("integrator_username"),
+			)
+
+		if filter.JobStartedWithin != "" {
+			// Paired with "OR start_date IS NULL" so a jobless app (the
+			// LeftJoin above leaves its job columns NULL) is kept rather
+			// than dropped by the job-start-date condition. That's correct
+			// for PopularFeaturedApps, which wants apps with no runs ranked
+			// alongside recently-run ones, but it means this condition alone
+			// is inclusive, not exclusive. RecentlyUsedApps relies on the
+			// RanByUsername join below to drop jobless apps instead; a
+			// caller that sets JobStartedWithin without RanByUsername (or an
+			// equivalent) will get jobless apps back too, sorted first on a
+			// Desc most-recent-start ordering since Postgres sorts NULL
+			// first in DESC.
+			interval := goqu.Cast(goqu.L(fmt.Sprintf("'%s'", filter.JobStartedWithin)), "interval")
+			query = query.Where(goqu.Or(
+				j.Col("start_date").Gte(goqu.L("now() - ?", interval)),
+				j.Col("start_date").IsNull(),
+			))
+		}
+		if filter.JobStartedFrom != nil {
+			query = query.Where(j.Col("start_date").Gte(*filter.JobStartedFrom))
+		}
+		if filter.JobStartedTo != nil {
+			query = query.Where(j.Col("start_date").Lte(*filter.JobStartedTo))
+		}
+		if filter.RanByUsername != "" {
+			u := goqu.T("users")
+			query = query.
+				Join(u, goqu.On(j.Col("user_id").Eq(u.Col("id")))).
+				Where(u.Col("username").Eq(filter.RanByUsername))
+		}
+	}
+
+	if needsCategoryJoin {
+		// An EXISTS subquery, not a Join, so an app in more than one of
+		// CategoryIDs is still matched once rather than once per matching
+		// category row — a plain join would multiply the app row (and, with
+		// needsJobJoin also true, every job row) before GROUP BY collapses
+		// it back down.
+		aca := goqu.T("app_category_app")
+		categorySubquery := db.From(aca).
+			Where(
+				aca.Col("app_id").Eq(a.Col("id")),
+				aca.Col("app_category_id").Eq(goqu.Any(pq.Array(filter.CategoryIDs))),
+			)
+		query = query.Where(goqu.L("EXISTS(?)", categorySubquery))
 	}
 
-	if querySettings.hasOffset {
-		query = query.Offset(querySettings.offset)
+	if filter.OnlyFavorites && filter.FavoritesUsername != "" {
+		query = query.Where(isFavoriteExpr)
+	}
+
+	if len(filter.AppIDs) > 0 {
+		appIDs := filter.AppIDs
+		if missingAppIDs != nil {
+			appIDs = missingAppIDs
+		}
+		query = query.Where(a.Col("id").Eq(goqu.Any(pq.Array(appIDs))))
+	}
+
+	if filter.IntegratorUsername != "" {
+		query = query.Where(a.Col("integrator_username").Eq(filter.IntegratorUsername))
+	}
+
+	if filter.NameMatches != "" {
+		query = query.Where(a.Col("name").ILike(fmt.Sprintf("%%%s%%", filter.NameMatches)))
+	}
+
+	if filter.DescriptionMatches != "" {
+		query = query.Where(a.Col("description").ILike(fmt.Sprintf("%%%s%%", filter.DescriptionMatches)))
+	}
+
+	if filter.IntegrationDateFrom != nil {
+		query = query.Where(a.Col("integration_date").Gte(*filter.IntegrationDateFrom))
+	}
+	if filter.IntegrationDateTo != nil {
+		query = query.Where(a.Col("integration_date").Lte(*filter.IntegrationDateTo))
+	}
+
+	query = query.Order(orderedExpression(sort))
+
+	// When a Cache is in play, the SQL only fetches the cache-miss rows, so
+	// a Limit/Offset applied here would be over that subset rather than the
+	// true merged result; sortAndPage applies it once, after merging with
+	// the cache hits, instead. Likewise with a SearchRanking, where paging
+	// applies to the ranked result rather than to the SQL order.
+	if cache == nil && len(filter.SearchRanking) == 0 {
+		if querySettings.hasLimit {
+			query = query.Limit(querySettings.limit)
+		}
+
+		if querySettings.hasOffset {
+			query = query.Offset(querySettings.offset)
+		}
 	}
 
 	executor := query.Executor()
@@ -107,305 +322,282 @@ func (d *Database) PopularFeaturedApps(ctx context.Context, cfg *AppsQueryConfig
 		return nil, err
 	}
 
-	return apps, err
-}
-
-func (d *Database) PopularFeaturedAppsAsync(ctx context.Context, appsChan chan []App, errChan chan error, cfg *AppsQueryConfig, opts ...QueryOption) {
-	log.Debug("getting popular featured apps")
-	apps, err := d.PopularFeaturedApps(ctx, cfg, opts...)
-	if err != nil {
-		log.Debug("errored getting popular featured apps")
-		errChan <- err
-		return
+	if cache != nil {
+		fetched := make(map[string]App, len(apps))
+		for _, a := range apps {
+			cacheable := a
+			// Never cache the per-caller fields; see the comment above
+			// the cache lookup in this function.
+			cacheable.IsFavorite = false
+			cacheable.IsPublic = false
+			fetched[a.ID] = cacheable
+		}
+		cache.BulkSet(fetched)
+
+		cachedApps := make([]App, 0, len(cacheHits))
+		for _, a := range cacheHits {
+			cachedApps = append(cachedApps, a)
+		}
+		favorites, favErr := d.favoriteAppIDs(ctx, db, filter.FavoritesUsername, filter.FavoritesGroupIndex)
+		if favErr != nil {
+			return nil, favErr
+		}
+		cachedApps = applyPerCallerFields(cachedApps, filter, favorites)
+
+		merged := make([]App, 0, len(apps)+len(cachedApps))
+		merged = append(merged, apps...)
+		merged = append(merged, cachedApps...)
+
+		return sortAndPage(merged, sort, filter.SearchRanking, querySettings), nil
 	}
-	log.Debug("got popular featured apps")
-	errChan <- nil
-	appsChan <- apps
-	log.Debug("done getting popular featured apps")
-}
-
-func (d *Database) PublicAppsQuery(ctx context.Context, username string, groupIndex int, publicAppIDs []string, opts ...QueryOption) ([]App, error) {
-	ctx, span := otel.Tracer(otelName).Start(ctx, "PublicAppsQuery")
-	defer span.End()
-
-	var (
-		err  error
-		db   GoquDatabase
-		apps []App
-	)
 
-	querySettings := &QuerySettings{}
-	for _, opt := range opts {
-		opt(querySettings)
+	if len(filter.SearchRanking) > 0 {
+		return sortAndPage(apps, sort, filter.SearchRanking, querySettings), nil
 	}
 
-	if querySettings.tx != nil {
-		db = querySettings.tx
-	} else {
-		db = d.goquDB
+	return apps, err
+}
+
+// favoriteAppIDs returns the set of app IDs in username's favorite category
+// tree (the same join ListApps uses to compute is_favorite in SQL), for
+// recomputing IsFavorite on rows served from the cache. It returns a nil
+// set, not an error, when username is empty.
+func (d *Database) favoriteAppIDs(ctx context.Context, gdb GoquDatabase, username string, groupIndex int) (map[string]bool, error) {
+	if username == "" {
+		return nil, nil
 	}
 
-	a := goqu.T("app_listing")
+	u := goqu.T("users")
 	w := goqu.T("workspace")
 	acg := goqu.T("app_category_group")
 	aca := goqu.T("app_category_app")
-	u := goqu.T("users")
 
-	subquery := db.From(u).
+	query := gdb.From(u).
 		Join(w, goqu.On(u.Col("id").Eq(w.Col("user_id")))).
 		Join(acg, goqu.On(w.Col("root_category_id").Eq(acg.Col("parent_category_id")))).
 		Join(aca, goqu.On(acg.Col("child_category_id").Eq(aca.Col("app_category_id")))).
+		Select(aca.Col("app_id")).
 		Where(
 			u.Col("username").Eq(username),
 			acg.Col("child_index").Eq(groupIndex),
-			aca.Col("app_id").Eq(a.Col("id")),
-		)
-
-	query := db.From(a).
-		Select(
-			a.Col("id"),
-			goqu.L(`'de'`).As(goqu.C("system_id")),
-			a.Col("name"),
-			a.Col("description"),
-			a.Col("wiki_url"),
-			a.Col("integration_date"),
-			a.Col("edited_date"),
-			a.Col("integrator_username").As(goqu.C("username")),
-			goqu.L("EXISTS(?)", subquery).As(goqu.C("is_favorite")),
-			goqu.L("true").As(goqu.C("is_public")),
-		).
-		Where(
-			a.Col("id").Eq(goqu.Any(pq.Array(publicAppIDs))),
-			a.Col("deleted").Eq(goqu.L("false")),
-			a.Col("disabled").Eq(goqu.L("false")),
-			a.Col("integration_date").IsNotNull(),
-		).
-		Order(
-			a.Col("integration_date").Desc(),
 		)
 
-	if querySettings.hasLimit {
-		query = query.Limit(querySettings.limit)
+	var ids []string
+	if err := query.Executor().ScanValsContext(ctx, &ids); err != nil {
+		return nil, err
 	}
 
-	if querySettings.hasOffset {
-		query = query.Offset(querySettings.offset)
+	favorites := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		favorites[id] = true
 	}
+	return favorites, nil
+}
 
-	executor := query.Executor()
-
-	apps = make([]App, 0)
-	if err = executor.ScanStructsContext(ctx, &apps); err != nil {
-		return nil, err
+// applyPerCallerFields recomputes IsFavorite/IsPublic on apps served from
+// the cache, since those fields are scoped to the current caller rather
+// than the app itself.
+func applyPerCallerFields(apps []App, filter *AppFilter, favorites map[string]bool) []App {
+	for i := range apps {
+		apps[i].IsFavorite = favorites[apps[i].ID]
+		apps[i].IsPublic = filter.OnlyPublic || containsString(filter.PublicAppIDs, apps[i].ID)
 	}
-
-	return apps, nil
+	return apps
 }
 
-func (d *Database) PublicAppsQueryAsync(ctx context.Context, appsChan chan []App, errChan chan error, username string, groupIndex int, publicAppIDs []string, opts ...QueryOption) {
-	log.Debug("getting public apps")
-	apps, err := d.PublicAppsQuery(ctx, username, groupIndex, publicAppIDs, opts...)
-	if err != nil {
-		log.Debug("errored getting public apps")
-		errChan <- err
-		return
+func containsString(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
 	}
-	log.Debug("got public apps")
-	errChan <- nil
-	appsChan <- apps
-	log.Debug("done getting public apps")
+	return false
 }
 
-func (d *Database) RecentlyAddedApps(ctx context.Context, username string, groupIndex int, publicAppIDS []string, opts ...QueryOption) ([]App, error) {
-	ctx, span := otel.Tracer(otelName).Start(ctx, "RecentlyAddedApps")
-	defer span.End()
-
-	var (
-		err  error
-		db   GoquDatabase
-		apps []App
-	)
-
-	querySettings := &QuerySettings{}
-	for _, opt := range opts {
-		opt(querySettings)
+// sortAndPage applies SortBy, or rankApps instead when ranking is set, and
+// then any configured limit/offset in-process. It backs the cache-hit path in
+// ListApps, where results come from a mix of the cache and SQL rather than a
+// single ordered query, and the SearchRanking path.
+func sortAndPage(apps []App, sort SortBy, ranking []SearchRanking, settings *QuerySettings) []App {
+	less := func(i, j int) bool {
+		switch sort.Field {
+		case SortByJobCount:
+			return apps[i].JobCount < apps[j].JobCount
+		case SortByMostRecentStart:
+			return timeOrZero(apps[i].MostRecentStartDate).Before(timeOrZero(apps[j].MostRecentStartDate))
+		case SortByName:
+			return apps[i].Name < apps[j].Name
+		default:
+			return timeOrZero(apps[i].IntegrationDate).Before(timeOrZero(apps[j].IntegrationDate))
+		}
 	}
-
-	if querySettings.tx != nil {
-		db = querySettings.tx
+	if len(ranking) > 0 {
+		apps = rankApps(apps, ranking)
+	} else if sort.Direction == Desc {
+		sortSlice(apps, func(i, j int) bool { return less(j, i) })
 	} else {
-		db = d.goquDB
+		sortSlice(apps, less)
 	}
 
-	a := goqu.T("app_listing")
-	w := goqu.T("workspace")
-	acg := goqu.T("app_category_group")
-	aca := goqu.T("app_category_app")
-	u := goqu.T("users")
+	if hasOffset, offset := settings.Offset(); hasOffset {
+		if offset >= len(apps) {
+			return nil
+		}
+		apps = apps[offset:]
+	}
+	if hasLimit, limit := settings.Limit(); hasLimit && limit < len(apps) {
+		apps = apps[:limit]
+	}
+	return apps
+}
 
-	subquery := db.From(u).
-		Join(w, goqu.On(u.Col("id").Eq(w.Col("user_id")))).
-		Join(acg, goqu.On(w.Col("root_category_id").Eq(acg.Col("parent_category_id")))).
-		Join(aca, goqu.On(acg.Col("child_category_id").Eq(aca.Col("app_category_id")))).
-		Where(
-			u.Col("username").Eq(username),
-			acg.Col("child_index").Eq(groupIndex),
-			aca.Col("app_id").Eq(a.Col("id")),
-		)
+func timeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
 
-	query := db.From(a).
-		Select(
-			a.Col("id"),
-			goqu.L(`'de'`).As(goqu.C("system_id")),
-			a.Col("name"),
-			a.Col("description"),
-			a.Col("wiki_url"),
-			a.Col("integration_date"),
-			a.Col("edited_date"),
-			a.Col("integrator_username").As(goqu.C("username")),
-			goqu.L("EXISTS(?)", subquery).As(goqu.C("is_favorite")),
-			a.Col("id").Eq(goqu.Any(pq.Array(publicAppIDS))).As(goqu.C("is_public")),
-		).
-		Where(
-			a.Col("deleted").Eq(goqu.L("false")),
-			a.Col("disabled").Eq(goqu.L("false")),
-			a.Col("integrator_username").Eq(username),
-		).
-		Order(
-			a.Col("integration_date").Desc(),
-		)
+func sortSlice(apps []App, less func(i, j int) bool) {
+	sort.SliceStable(apps, less)
+}
 
-	if querySettings.hasLimit {
-		query = query.Limit(querySettings.limit)
+// rankApps narrows apps to those named in ranking and reorders them by
+// descending score, letting a search engine drive relevance while SQL still
+// applies the favorites/public/jobs joins.
+func rankApps(apps []App, ranking []SearchRanking) []App {
+	scores := make(map[string]float64, len(ranking))
+	for _, r := range ranking {
+		scores[r.AppID] = r.Score
 	}
 
-	if querySettings.hasOffset {
-		query = query.Offset(querySettings.offset)
+	ranked := make([]App, 0, len(apps))
+	for _, a := range apps {
+		if _, ok := scores[a.ID]; ok {
+			ranked = append(ranked, a)
+		}
 	}
 
-	log.Debug("done generating query for recently added apps")
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return scores[ranked[i].ID] > scores[ranked[j].ID]
+	})
 
-	executor := query.Executor()
+	return ranked
+}
 
-	apps = make([]App, 0)
-	if err = executor.ScanStructsContext(ctx, &apps); err != nil {
-		return nil, err
+func orderedExpression(sort SortBy) exp.OrderedExpression {
+	var col exp.IdentifierExpression
+	switch sort.Field {
+	case SortByJobCount:
+		col = goqu.C("job_count")
+	case SortByMostRecentStart:
+		col = goqu.C("most_recent_start_date")
+	case SortByName:
+		col = goqu.T("app_listing").Col("name")
+	default:
+		col = goqu.T("app_listing").Col("integration_date")
 	}
 
-	log.Debug("done running/scanning query for recently added apps")
+	if sort.Direction == Desc {
+		return col.Desc()
+	}
+	return col.Asc()
+}
 
-	return apps, nil
+func (d *Database) PopularFeaturedApps(ctx context.Context, cfg *AppsQueryConfig, opts ...QueryOption) ([]App, error) {
+	filter := &AppFilter{
+		AppIDs:              cfg.AppIDs,
+		OnlyPublic:          true,
+		JobStartedWithin:    cfg.StartDateInterval,
+		FavoritesUsername:   cfg.Username,
+		FavoritesGroupIndex: cfg.GroupsIndex,
+		SearchRanking:       cfg.SearchRanking,
+	}
+	return d.ListApps(ctx, filter, SortBy{Field: SortByJobCount, Direction: Desc}, opts...)
 }
 
-func (d *Database) RecentlyAddedAppsAsync(ctx context.Context, appsChan chan []App, errChan chan error, username string, groupIndex int, publicAppIDS []string, opts ...QueryOption) {
-	log.Debug("getting recently added apps")
-	apps, err := d.RecentlyAddedApps(ctx, username, groupIndex, publicAppIDS, opts...)
+// Deprecated: use AggregateDashboard instead, which replaces this
+// errChan/appsChan fan-out with an errgroup and can't strand a sender.
+func (d *Database) PopularFeaturedAppsAsync(ctx context.Context, appsChan chan []App, errChan chan error, cfg *AppsQueryConfig, opts ...QueryOption) {
+	log.Debug("getting popular featured apps")
+	apps, err := d.PopularFeaturedApps(ctx, cfg, opts...)
 	if err != nil {
-		log.Debug("error getting recently added apps")
+		log.Debug("errored getting popular featured apps")
 		errChan <- err
 		return
 	}
-	log.Debug("got recently added apps")
+	log.Debug("got popular featured apps")
 	errChan <- nil
 	appsChan <- apps
-	log.Debug("done getting recently added apps")
+	log.Debug("done getting popular featured apps")
 }
 
-func (d *Database) RecentlyUsedApps(ctx context.Context, cfg *AppsQueryConfig, opts ...QueryOption) ([]App, error) {
-	ctx, span := otel.Tracer(otelName).Start(ctx, "RecentlyUsedApps")
-	defer span.End()
-
-	var (
-		err  error
-		db   GoquDatabase
-		apps []App
-	)
-
-	querySettings := &QuerySettings{}
-	for _, opt := range opts {
-		opt(querySettings)
+func (d *Database) PublicAppsQuery(ctx context.Context, username string, groupIndex int, publicAppIDs []string, opts ...QueryOption) ([]App, error) {
+	filter := &AppFilter{
+		AppIDs:              publicAppIDs,
+		OnlyPublic:          true,
+		FavoritesUsername:   username,
+		FavoritesGroupIndex: groupIndex,
 	}
+	return d.ListApps(ctx, filter, SortBy{Field: SortByIntegrationDate, Direction: Desc}, opts...)
+}
 
-	if querySettings.tx != nil {
-		db = querySettings.tx
-	} else {
-		db = d.goquDB
+// Deprecated: use AggregateDashboard instead, which replaces this
+// errChan/appsChan fan-out with an errgroup and can't strand a sender.
+func (d *Database) PublicAppsQueryAsync(ctx context.Context, appsChan chan []App, errChan chan error, username string, groupIndex int, publicAppIDs []string, opts ...QueryOption) {
+	log.Debug("getting public apps")
+	apps, err := d.PublicAppsQuery(ctx, username, groupIndex, publicAppIDs, opts...)
+	if err != nil {
+		log.Debug("errored getting public apps")
+		errChan <- err
+		return
 	}
+	log.Debug("got public apps")
+	errChan <- nil
+	appsChan <- apps
+	log.Debug("done getting public apps")
+}
 
-	a := goqu.T("app_listing")
-	j := goqu.T("jobs")
-	w := goqu.T("workspace")
-	acg := goqu.T("app_category_group")
-	aca := goqu.T("app_category_app")
-	u := goqu.T("users")
-
-	subquery := db.From(u).
-		Join(w, goqu.On(u.Col("id").Eq(w.Col("user_id")))).
-		Join(acg, goqu.On(w.Col("root_category_id").Eq(acg.Col("parent_category_id")))).
-		Join(aca, goqu.On(acg.Col("child_category_id").Eq(aca.Col("app_category_id")))).
-		Where(
-			u.Col("username").Eq(cfg.Username),
-			acg.Col("child_index").Eq(cfg.GroupsIndex),
-			aca.Col("app_id").Eq(a.Col("id")),
-		)
-
-	query := db.From(j).
-		Select(
-			a.Col("id"),
-			goqu.L(`'de'`).As(goqu.C("system_id")),
-			a.Col("name"),
-			a.Col("description"),
-			a.Col("wiki_url"),
-			a.Col("integration_date"),
-			a.Col("edited_date"),
-			a.Col("integrator_username").As(goqu.C("username")),
-			goqu.L("EXISTS(?)", subquery).As(goqu.C("is_favorite")),
-			a.Col("id").Eq(goqu.Any(pq.Array(cfg.AppIDs))).As(goqu.C("is_public")),
-			goqu.MAX(j.Col("start_date")).As(goqu.C("most_recent_start_date")),
-		).
-		Join(u, goqu.On(j.Col("user_id").Eq(u.Col("id")))).
-		Join(a, goqu.On(goqu.Cast(a.Col("id"), "TEXT").Eq(j.Col("app_id")))).
-		Where(
-			u.Col("username").Eq(cfg.Username),
-			a.Col("deleted").IsFalse(),
-			a.Col("disabled").IsFalse(),
-			j.Col("start_date").Gt(goqu.L("now() - ?", goqu.Cast(goqu.L(fmt.Sprintf("'%s'", cfg.StartDateInterval)), "INTERVAL"))),
-		).
-		GroupBy(
-			a.Col("id"),
-			a.Col("name"),
-			a.Col("description"),
-			a.Col("wiki_url"),
-			a.Col("integration_date"),
-			a.Col("edited_date"),
-			a.Col("integrator_username"),
-		).
-		Order(
-			goqu.C("most_recent_start_date").Desc(),
-		)
-
-	if querySettings.hasLimit {
-		query = query.Limit(querySettings.limit)
+func (d *Database) RecentlyAddedApps(ctx context.Context, cfg *AppsQueryConfig, opts ...QueryOption) ([]App, error) {
+	filter := &AppFilter{
+		IntegratorUsername:  cfg.Username,
+		PublicAppIDs:        cfg.AppIDs,
+		FavoritesUsername:   cfg.Username,
+		FavoritesGroupIndex: cfg.GroupsIndex,
+		SearchRanking:       cfg.SearchRanking,
 	}
+	return d.ListApps(ctx, filter, SortBy{Field: SortByIntegrationDate, Direction: Desc}, opts...)
+}
 
-	if querySettings.hasOffset {
-		query = query.Offset(querySettings.offset)
+// Deprecated: use AggregateDashboard instead, which replaces this
+// errChan/appsChan fan-out with an errgroup and can't strand a sender.
+func (d *Database) RecentlyAddedAppsAsync(ctx context.Context, appsChan chan []App, errChan chan error, cfg *AppsQueryConfig, opts ...QueryOption) {
+	log.Debug("getting recently added apps")
+	apps, err := d.RecentlyAddedApps(ctx, cfg, opts...)
+	if err != nil {
+		log.Debug("error getting recently added apps")
+		errChan <- err
+		return
 	}
+	log.Debug("got recently added apps")
+	errChan <- nil
+	appsChan <- apps
+	log.Debug("done getting recently added apps")
+}
 
-	log.Debug("done generating query for recently used apps")
-
-	executor := query.Executor()
-
-	apps = make([]App, 0)
-	if err = executor.ScanStructsContext(ctx, &apps); err != nil {
-		return nil, err
+func (d *Database) RecentlyUsedApps(ctx context.Context, cfg *AppsQueryConfig, opts ...QueryOption) ([]App, error) {
+	filter := &AppFilter{
+		RanByUsername:       cfg.Username,
+		PublicAppIDs:        cfg.AppIDs,
+		JobStartedWithin:    cfg.StartDateInterval,
+		FavoritesUsername:   cfg.Username,
+		FavoritesGroupIndex: cfg.GroupsIndex,
 	}
-
-	log.Debug("done running/scanning query for recently used apps")
-
-	return apps, nil
+	return d.ListApps(ctx, filter, SortBy{Field: SortByMostRecentStart, Direction: Desc}, opts...)
 }
 
+// Deprecated: use AggregateDashboard instead, which replaces this
+// errChan/appsChan fan-out with an errgroup and can't strand a sender.
 func (d *Database) RecentlyUsedAppsAsync(ctx context.Context, appsChan chan []App, errChan chan error, cfg *AppsQueryConfig, opts ...QueryOption) {
 	log.Debug("getting recently used apps")
 	apps, err := d.RecentlyUsedApps(ctx, cfg, opts...)
@@ -0,0 +1,27 @@
+package db
+
+// Cache lets ListApps consult an in-process cache for a batch of app IDs
+// before falling back to SQL for whatever it doesn't have. The cache
+// package provides an LRU+TTL-backed implementation; tests can use any
+// implementation that satisfies this interface.
+type Cache interface {
+	// BulkGet returns the apps found in the cache, and the subset of ids
+	// that still need to be fetched from the database.
+	BulkGet(ids []string) (hits map[string]App, missing []string)
+	// BulkSet populates the cache with freshly fetched apps, keyed by id.
+	BulkSet(apps map[string]App)
+}
+
+// WithCache consults cache for the AppFilter's AppIDs before issuing SQL,
+// populating it with whatever had to be fetched. It's a no-op for calls
+// that don't filter by a known set of AppIDs.
+func WithCache(c Cache) QueryOption {
+	return func(s *QuerySettings) {
+		s.cache = c
+	}
+}
+
+// Cache reports the configured Cache, if any.
+func (s *QuerySettings) Cache() Cache {
+	return s.cache
+}
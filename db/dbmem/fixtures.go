@@ -0,0 +1,49 @@
+// Package dbmem is an in-memory fake of db.Store, backed by plain Go slices.
+// It's intended for unit tests that want deterministic fixtures without
+// standing up a real Postgres instance.
+package dbmem
+
+import "time"
+
+// App is a fixture row mirroring the app_listing table.
+type App struct {
+	ID                 string
+	Name               string
+	Description        string
+	WikiURL            string
+	IntegratorUsername string
+	IntegrationDate    *time.Time
+	EditedDate         *time.Time
+	Deleted            bool
+	Disabled           bool
+}
+
+// Job is a fixture row mirroring the jobs table.
+type Job struct {
+	ID        string
+	AppID     string
+	UserID    string
+	StartDate time.Time
+}
+
+// Workspace is a fixture row mirroring the workspace table.
+type Workspace struct {
+	UserID         string
+	RootCategoryID string
+}
+
+// Category is a fixture row combining app_category_group and
+// app_category_app: it links a parent category to a child category that a
+// set of apps belong to.
+type Category struct {
+	ID               int
+	ParentCategoryID string
+	ChildIndex       int
+	AppIDs           []string
+}
+
+// User is a fixture row mirroring the users table.
+type User struct {
+	ID       string
+	Username string
+}
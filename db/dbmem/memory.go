@@ -0,0 +1,379 @@
+package dbmem
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sboleyn/dashboard-aggregator/db"
+)
+
+// Memory is an in-memory db.Store backed by plain Go slices, guarded by a
+// single RWMutex. It's meant for tests: construct one with New(), seed it
+// with Add*, and pass it anywhere a db.Store is expected.
+type Memory struct {
+	mu         sync.RWMutex
+	apps       []App
+	jobs       []Job
+	workspaces []Workspace
+	categories []Category
+	users      []User
+}
+
+// New returns an empty in-memory store.
+func New() *Memory {
+	return &Memory{}
+}
+
+func (m *Memory) AddApp(a App) *Memory {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.apps = append(m.apps, a)
+	return m
+}
+
+func (m *Memory) AddJob(j Job) *Memory {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jobs = append(m.jobs, j)
+	return m
+}
+
+func (m *Memory) AddWorkspace(w Workspace) *Memory {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.workspaces = append(m.workspaces, w)
+	return m
+}
+
+func (m *Memory) AddCategory(c Category) *Memory {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.categories = append(m.categories, c)
+	return m
+}
+
+func (m *Memory) AddUser(u User) *Memory {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.users = append(m.users, u)
+	return m
+}
+
+var _ db.Store = (*Memory)(nil)
+
+func (m *Memory) ListApps(_ context.Context, filter *db.AppFilter, sortBy db.SortBy, opts ...db.QueryOption) ([]db.App, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	settings := &db.QuerySettings{}
+	for _, opt := range opts {
+		opt(settings)
+	}
+
+	favoriteAppIDs := m.favoriteAppIDs(filter.FavoritesUsername, filter.FavoritesGroupIndex)
+
+	result := make([]db.App, 0, len(m.apps))
+	for _, a := range m.apps {
+		if a.Deleted || a.Disabled || a.IntegrationDate == nil {
+			continue
+		}
+		if len(filter.AppIDs) > 0 && !contains(filter.AppIDs, a.ID) {
+			continue
+		}
+		if filter.IntegratorUsername != "" && a.IntegratorUsername != filter.IntegratorUsername {
+			continue
+		}
+		if filter.NameMatches != "" && !containsFold(a.Name, filter.NameMatches) {
+			continue
+		}
+		if filter.DescriptionMatches != "" && !containsFold(a.Description, filter.DescriptionMatches) {
+			continue
+		}
+		if len(filter.CategoryIDs) > 0 && !m.inAnyCategory(a.ID, filter.CategoryIDs) {
+			continue
+		}
+		if filter.IntegrationDateFrom != nil && a.IntegrationDate.Before(*filter.IntegrationDateFrom) {
+			continue
+		}
+		if filter.IntegrationDateTo != nil && a.IntegrationDate.After(*filter.IntegrationDateTo) {
+			continue
+		}
+
+		isFavorite := contains(favoriteAppIDs, a.ID)
+		if filter.OnlyFavorites && !isFavorite {
+			continue
+		}
+
+		jobCount, mostRecentStart, ok := m.jobStats(a.ID, filter)
+		if !ok {
+			continue
+		}
+
+		isPublic := filter.OnlyPublic || contains(filter.PublicAppIDs, a.ID)
+
+		result = append(result, db.App{
+			ID:                  a.ID,
+			SystemID:            "de",
+			Name:                a.Name,
+			Description:         a.Description,
+			WikiURL:             a.WikiURL,
+			IntegrationDate:     a.IntegrationDate,
+			EditedDate:          a.EditedDate,
+			Username:            a.IntegratorUsername,
+			JobCount:            jobCount,
+			IsFavorite:          isFavorite,
+			IsPublic:            isPublic,
+			MostRecentStartDate: mostRecentStart,
+		})
+	}
+
+	if len(filter.SearchRanking) > 0 {
+		result = rankApps(result, filter.SearchRanking)
+	} else {
+		sortApps(result, sortBy)
+	}
+
+	if hasLimit, limit := settings.Limit(); hasLimit {
+		if hasOffset, offset := settings.Offset(); hasOffset && offset < len(result) {
+			result = result[offset:]
+		} else if hasOffset {
+			result = nil
+		}
+		if limit < len(result) {
+			result = result[:limit]
+		}
+	} else if hasOffset, offset := settings.Offset(); hasOffset {
+		if offset < len(result) {
+			result = result[offset:]
+		} else {
+			result = nil
+		}
+	}
+
+	return result, nil
+}
+
+// jobStats returns the job count and most recent start date for app appID
+// given the job-related filters, mirroring the LEFT JOIN ... WHERE (start
+// matches OR no job at all) semantics used by the Postgres-backed Store. ok
+// is false when the app has jobs but none of them satisfy the filters (the
+// app should be dropped entirely).
+func (m *Memory) jobStats(appID string, filter *db.AppFilter) (count int, mostRecent *time.Time, ok bool) {
+	var all, matching []Job
+	for _, j := range m.jobs {
+		if j.AppID != appID {
+			continue
+		}
+		all = append(all, j)
+		if filter.RanByUsername != "" && m.username(j.UserID) != filter.RanByUsername {
+			continue
+		}
+		if filter.JobStartedWithin != "" {
+			if cutoff, parsed := parseInterval(filter.JobStartedWithin); parsed && j.StartDate.Before(cutoff) {
+				continue
+			}
+		}
+		if filter.JobStartedFrom != nil && j.StartDate.Before(*filter.JobStartedFrom) {
+			continue
+		}
+		if filter.JobStartedTo != nil && j.StartDate.After(*filter.JobStartedTo) {
+			continue
+		}
+		matching = append(matching, j)
+	}
+
+	// RanByUsername and JobStartedFrom/To are applied as plain WHERE
+	// clauses against the (possibly NULL, for a jobless app) joined job
+	// row in the real Store, so a jobless app is dropped whenever any of
+	// them is set — the same as when it has jobs but none qualify.
+	// JobStartedWithin alone is paired with "OR start_date IS NULL", so it
+	// keeps jobless apps.
+	needsJobFilter := filter.RanByUsername != "" || filter.JobStartedFrom != nil || filter.JobStartedTo != nil
+	if len(all) == 0 {
+		if needsJobFilter {
+			return 0, nil, false
+		}
+		return 0, nil, true
+	}
+	if len(matching) == 0 {
+		if needsJobFilter {
+			return 0, nil, false
+		}
+		if filter.JobStartedWithin != "" {
+			return 0, nil, false
+		}
+		return 0, nil, true
+	}
+
+	count = len(matching)
+	for _, j := range matching {
+		t := j.StartDate
+		if mostRecent == nil || t.After(*mostRecent) {
+			mostRecent = &t
+		}
+	}
+	return count, mostRecent, true
+}
+
+func (m *Memory) username(userID string) string {
+	for _, u := range m.users {
+		if u.ID == userID {
+			return u.Username
+		}
+	}
+	return ""
+}
+
+// favoriteAppIDs returns the apps reachable from username's workspace root
+// category, at the given child group index.
+func (m *Memory) favoriteAppIDs(username string, groupIndex int) []string {
+	if username == "" {
+		return nil
+	}
+	var userID string
+	for _, u := range m.users {
+		if u.Username == username {
+			userID = u.ID
+			break
+		}
+	}
+	if userID == "" {
+		return nil
+	}
+	var rootCategoryID string
+	for _, w := range m.workspaces {
+		if w.UserID == userID {
+			rootCategoryID = w.RootCategoryID
+			break
+		}
+	}
+	if rootCategoryID == "" {
+		return nil
+	}
+	var appIDs []string
+	for _, c := range m.categories {
+		if c.ParentCategoryID == rootCategoryID && c.ChildIndex == groupIndex {
+			appIDs = append(appIDs, c.AppIDs...)
+		}
+	}
+	return appIDs
+}
+
+func (m *Memory) inAnyCategory(appID string, categoryIDs []int) bool {
+	for _, c := range m.categories {
+		if !containsInt(categoryIDs, c.ID) {
+			continue
+		}
+		if contains(c.AppIDs, appID) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt(haystack []int, needle int) bool {
+	for _, n := range haystack {
+		if n == needle {
+			return true
+		}
+	}
+	return false
+}
+
+// rankApps narrows apps to those named by ranking and reorders them by
+// descending score, mirroring db.rankApps so Memory behaves the same as the
+// Postgres-backed Database when a caller sets AppFilter.SearchRanking.
+func rankApps(apps []db.App, ranking []db.SearchRanking) []db.App {
+	scores := make(map[string]float64, len(ranking))
+	for _, r := range ranking {
+		scores[r.AppID] = r.Score
+	}
+
+	ranked := make([]db.App, 0, len(apps))
+	for _, a := range apps {
+		if _, ok := scores[a.ID]; ok {
+			ranked = append(ranked, a)
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return scores[ranked[i].ID] > scores[ranked[j].ID]
+	})
+
+	return ranked
+}
+
+func sortApps(apps []db.App, sortBy db.SortBy) {
+	less := func(i, j int) bool {
+		switch sortBy.Field {
+		case db.SortByJobCount:
+			return apps[i].JobCount < apps[j].JobCount
+		case db.SortByMostRecentStart:
+			return timeOrZero(apps[i].MostRecentStartDate).Before(timeOrZero(apps[j].MostRecentStartDate))
+		case db.SortByName:
+			return apps[i].Name < apps[j].Name
+		default:
+			return timeOrZero(apps[i].IntegrationDate).Before(timeOrZero(apps[j].IntegrationDate))
+		}
+	}
+	if sortBy.Direction == db.Desc {
+		sort.SliceStable(apps, func(i, j int) bool { return less(j, i) })
+		return
+	}
+	sort.SliceStable(apps, less)
+}
+
+func timeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
+func contains(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(haystack, needle string) bool {
+	return strings.Contains(strings.ToLower(haystack), strings.ToLower(needle))
+}
+
+// parseInterval turns a handful of common Postgres interval literals (as
+// used by JobStartedWithin, e.g. "30 days", "2 hours") into a cutoff time.
+// It returns ok=false for anything it doesn't recognize, in which case the
+// caller should treat the filter as not applying.
+func parseInterval(interval string) (cutoff time.Time, ok bool) {
+	fields := strings.Fields(interval)
+	if len(fields) != 2 {
+		return time.Time{}, false
+	}
+	n, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return time.Time{}, false
+	}
+	var unit time.Duration
+	switch strings.TrimSuffix(strings.ToLower(fields[1]), "s") {
+	case "second":
+		unit = time.Second
+	case "minute":
+		unit = time.Minute
+	case "hour":
+		unit = time.Hour
+	case "day":
+		unit = 24 * time.Hour
+	case "week":
+		unit = 7 * 24 * time.Hour
+	default:
+		return time.Time{}, false
+	}
+	return time.Now().Add(-time.Duration(n) * unit), true
+}
@@ -0,0 +1,148 @@
+package dbmem_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sboleyn/dashboard-aggregator/db"
+	"github.com/sboleyn/dashboard-aggregator/db/dbmem"
+	"github.com/sboleyn/dashboard-aggregator/db/storetest"
+)
+
+var (
+	fixtureT1 = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	fixtureT2 = time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// newFixtureMemory builds the in-memory fixture used by the dbmem-only
+// cases below.
+func newFixtureMemory() *dbmem.Memory {
+	return dbmem.New().
+		AddApp(dbmem.App{ID: "app-1", Name: "Widget Tracker", Description: "tracks widgets", IntegratorUsername: "alice", IntegrationDate: &fixtureT1}).
+		AddApp(dbmem.App{ID: "app-2", Name: "Gadget Dashboard", Description: "dashboards for gadgets", IntegratorUsername: "bob", IntegrationDate: &fixtureT2}).
+		AddApp(dbmem.App{ID: "app-3", Name: "Deleted App", IntegratorUsername: "bob", IntegrationDate: &fixtureT1, Deleted: true}).
+		AddApp(dbmem.App{ID: "app-4", Name: "Jobless App", IntegratorUsername: "bob", IntegrationDate: &fixtureT1}).
+		AddUser(dbmem.User{ID: "u-alice", Username: "alice"}).
+		AddUser(dbmem.User{ID: "u-bob", Username: "bob"}).
+		AddWorkspace(dbmem.Workspace{UserID: "u-alice", RootCategoryID: "root-1"}).
+		AddCategory(dbmem.Category{ID: 7, ParentCategoryID: "root-1", ChildIndex: 0, AppIDs: []string{"app-1"}}).
+		AddCategory(dbmem.Category{ID: 8, ParentCategoryID: "root-2", ChildIndex: 0, AppIDs: []string{"app-1"}}).
+		AddJob(dbmem.Job{ID: "job-1", AppID: "app-1", UserID: "u-alice", StartDate: time.Now().Add(-time.Hour)}).
+		AddJob(dbmem.Job{ID: "job-2", AppID: "app-1", UserID: "u-alice", StartDate: time.Now().Add(-48 * time.Hour)}).
+		AddJob(dbmem.Job{ID: "job-3", AppID: "app-2", UserID: "u-bob", StartDate: time.Now().Add(-30 * 24 * time.Hour)})
+}
+
+func newFixtureStore() *dbmem.Memory {
+	return newFixtureMemory()
+}
+
+func TestMemoryListApps_FiltersDeletedAndMatchesName(t *testing.T) {
+	store := newFixtureStore()
+
+	apps, err := store.ListApps(context.Background(), &db.AppFilter{NameMatches: "gadget"}, db.SortBy{Field: db.SortByName})
+	if err != nil {
+		t.Fatalf("ListApps returned error: %v", err)
+	}
+	if len(apps) != 1 || apps[0].ID != "app-2" {
+		t.Fatalf("expected only app-2 to match, got %+v", apps)
+	}
+}
+
+func TestMemoryListApps_OnlyFavoritesUsesCategoryTree(t *testing.T) {
+	store := newFixtureStore()
+
+	apps, err := store.ListApps(context.Background(), &db.AppFilter{
+		OnlyFavorites:       true,
+		FavoritesUsername:   "alice",
+		FavoritesGroupIndex: 0,
+	}, db.SortBy{Field: db.SortByName})
+	if err != nil {
+		t.Fatalf("ListApps returned error: %v", err)
+	}
+	if len(apps) != 1 || apps[0].ID != "app-1" || !apps[0].IsFavorite {
+		t.Fatalf("expected only app-1 as a favorite, got %+v", apps)
+	}
+}
+
+func TestMemoryListApps_SortAndLimit(t *testing.T) {
+	store := newFixtureStore()
+
+	apps, err := store.ListApps(context.Background(), &db.AppFilter{}, db.SortBy{Field: db.SortByIntegrationDate, Direction: db.Desc}, db.WithLimit(1))
+	if err != nil {
+		t.Fatalf("ListApps returned error: %v", err)
+	}
+	if len(apps) != 1 || apps[0].ID != "app-2" {
+		t.Fatalf("expected app-2 first by integration date desc, got %+v", apps)
+	}
+}
+
+func TestMemoryListApps_JobCountAndMostRecentStart(t *testing.T) {
+	store := newFixtureStore()
+
+	apps, err := store.ListApps(context.Background(), &db.AppFilter{IntegratorUsername: "alice"}, db.SortBy{Field: db.SortByMostRecentStart, Direction: db.Desc})
+	if err != nil {
+		t.Fatalf("ListApps returned error: %v", err)
+	}
+	if len(apps) != 1 || apps[0].ID != "app-1" {
+		t.Fatalf("expected only app-1, got %+v", apps)
+	}
+	if apps[0].JobCount != 2 {
+		t.Fatalf("expected job_count 2, got %d", apps[0].JobCount)
+	}
+	if apps[0].MostRecentStartDate == nil {
+		t.Fatalf("expected a most recent start date")
+	}
+}
+
+func TestMemoryListApps_RanByUsernameExcludesJoblessApps(t *testing.T) {
+	store := newFixtureStore()
+
+	apps, err := store.ListApps(context.Background(), &db.AppFilter{RanByUsername: "bob"}, db.SortBy{Field: db.SortByName})
+	if err != nil {
+		t.Fatalf("ListApps returned error: %v", err)
+	}
+	ids := make([]string, 0, len(apps))
+	for _, a := range apps {
+		ids = append(ids, a.ID)
+	}
+	if len(apps) != 1 || apps[0].ID != "app-2" {
+		t.Fatalf("expected only app-2 (bob's job), got %v", ids)
+	}
+}
+
+func TestMemoryListApps_JobStartedWithinKeepsJoblessApps(t *testing.T) {
+	store := newFixtureStore()
+
+	apps, err := store.ListApps(context.Background(), &db.AppFilter{JobStartedWithin: "2 days"}, db.SortBy{Field: db.SortByName})
+	if err != nil {
+		t.Fatalf("ListApps returned error: %v", err)
+	}
+	ids := make(map[string]bool, len(apps))
+	for _, a := range apps {
+		ids[a.ID] = true
+	}
+	// app-1 has a job within the window, app-2's only job is 30 days old
+	// (excluded), app-4 has no jobs at all but must still be kept.
+	if !ids["app-1"] || ids["app-2"] || !ids["app-4"] {
+		t.Fatalf("expected app-1 and app-4 but not app-2, got %v", ids)
+	}
+}
+
+// TestMemoryListApps_Conformance runs the shared db.Store conformance
+// suite (db/storetest) against dbmem; db/integration_test.go runs the same
+// suite against a real Postgres-backed Database.
+func TestMemoryListApps_Conformance(t *testing.T) {
+	storetest.Run(t, newFixtureMemory(), storetest.Seed{
+		GadgetAppID:           "app-2",
+		FavoritesUsername:     "alice",
+		FavoritesGroupIndex:   0,
+		FavoritedAppID:        "app-1",
+		JobRunnerUsername:     "bob",
+		JobRunnerAppID:        "app-2",
+		RankedAppIDs:          []string{"app-1", "app-2"},
+		MultiCategoryAppID:    "app-1",
+		MultiCategoryIDs:      []int{7, 8},
+		MultiCategoryJobCount: 2,
+	})
+}
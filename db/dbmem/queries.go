@@ -0,0 +1,55 @@
+package dbmem
+
+import (
+	"context"
+
+	"github.com/sboleyn/dashboard-aggregator/db"
+)
+
+// These mirror Database's thin wrappers in db/apps.go exactly, filter field
+// for filter field, so fixtures exercised against Memory behave the same way
+// they would against the real Store.
+
+func (m *Memory) PopularFeaturedApps(ctx context.Context, cfg *db.AppsQueryConfig, opts ...db.QueryOption) ([]db.App, error) {
+	filter := &db.AppFilter{
+		AppIDs:              cfg.AppIDs,
+		OnlyPublic:          true,
+		JobStartedWithin:    cfg.StartDateInterval,
+		FavoritesUsername:   cfg.Username,
+		FavoritesGroupIndex: cfg.GroupsIndex,
+		SearchRanking:       cfg.SearchRanking,
+	}
+	return m.ListApps(ctx, filter, db.SortBy{Field: db.SortByJobCount, Direction: db.Desc}, opts...)
+}
+
+func (m *Memory) PublicAppsQuery(ctx context.Context, username string, groupIndex int, publicAppIDs []string, opts ...db.QueryOption) ([]db.App, error) {
+	filter := &db.AppFilter{
+		AppIDs:              publicAppIDs,
+		OnlyPublic:          true,
+		FavoritesUsername:   username,
+		FavoritesGroupIndex: groupIndex,
+	}
+	return m.ListApps(ctx, filter, db.SortBy{Field: db.SortByIntegrationDate, Direction: db.Desc}, opts...)
+}
+
+func (m *Memory) RecentlyAddedApps(ctx context.Context, cfg *db.AppsQueryConfig, opts ...db.QueryOption) ([]db.App, error) {
+	filter := &db.AppFilter{
+		IntegratorUsername:  cfg.Username,
+		PublicAppIDs:        cfg.AppIDs,
+		FavoritesUsername:   cfg.Username,
+		FavoritesGroupIndex: cfg.GroupsIndex,
+		SearchRanking:       cfg.SearchRanking,
+	}
+	return m.ListApps(ctx, filter, db.SortBy{Field: db.SortByIntegrationDate, Direction: db.Desc}, opts...)
+}
+
+func (m *Memory) RecentlyUsedApps(ctx context.Context, cfg *db.AppsQueryConfig, opts ...db.QueryOption) ([]db.App, error) {
+	filter := &db.AppFilter{
+		RanByUsername:       cfg.Username,
+		PublicAppIDs:        cfg.AppIDs,
+		JobStartedWithin:    cfg.StartDateInterval,
+		FavoritesUsername:   cfg.Username,
+		FavoritesGroupIndex: cfg.GroupsIndex,
+	}
+	return m.ListApps(ctx, filter, db.SortBy{Field: db.SortByMostRecentStart, Direction: db.Desc}, opts...)
+}
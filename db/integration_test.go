@@ -0,0 +1,135 @@
+//go:build integration
+
+package db_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	_ "github.com/doug-martin/goqu/v9/dialect/postgres"
+	_ "github.com/lib/pq"
+
+	"github.com/sboleyn/dashboard-aggregator/cache"
+	"github.com/sboleyn/dashboard-aggregator/db"
+	"github.com/sboleyn/dashboard-aggregator/db/storetest"
+)
+
+// TestDatabaseListApps_Conformance runs the same db.Store suite dbmem uses
+// (db/dbmem/memory_test.go) against a real Postgres-backed Database, seeded
+// with matching fixtures, so the two Store implementations are checked
+// against identical cases. It requires DASHBOARD_TEST_DATABASE_URL to point
+// at a scratch database and is skipped otherwise.
+func TestDatabaseListApps_Conformance(t *testing.T) {
+	dsn := os.Getenv("DASHBOARD_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("DASHBOARD_TEST_DATABASE_URL not set; skipping Postgres conformance run")
+	}
+
+	sqlDB, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("opening test database: %v", err)
+	}
+	defer sqlDB.Close()
+
+	if err := seedConformanceFixtures(sqlDB); err != nil {
+		t.Fatalf("seeding fixtures: %v", err)
+	}
+
+	store := db.NewDatabase(goqu.New("postgres", sqlDB))
+
+	storetest.Run(t, store, storetest.Seed{
+		GadgetAppID:           "app-2",
+		FavoritesUsername:     "alice",
+		FavoritesGroupIndex:   0,
+		FavoritedAppID:        "app-1",
+		JobRunnerUsername:     "bob",
+		JobRunnerAppID:        "app-2",
+		RankedAppIDs:          []string{"app-1", "app-2"},
+		MultiCategoryAppID:    "app-1",
+		MultiCategoryIDs:      []int{7, 8},
+		MultiCategoryJobCount: 2,
+	})
+}
+
+// TestDatabaseListApps_CacheHonorsLimitOffsetAcrossMergedResults guards
+// against Limit/Offset being applied both to the SQL query fetching
+// cache-miss rows and again by sortAndPage over the merged (cache hits + SQL
+// rows) result, which would silently drop or mis-page valid rows whenever
+// the cache is warm.
+func TestDatabaseListApps_CacheHonorsLimitOffsetAcrossMergedResults(t *testing.T) {
+	dsn := os.Getenv("DASHBOARD_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("DASHBOARD_TEST_DATABASE_URL not set; skipping Postgres conformance run")
+	}
+
+	sqlDB, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("opening test database: %v", err)
+	}
+	defer sqlDB.Close()
+
+	if err := seedConformanceFixtures(sqlDB); err != nil {
+		t.Fatalf("seeding fixtures: %v", err)
+	}
+
+	store := db.NewDatabase(goqu.New("postgres", sqlDB))
+
+	// Pre-warm the cache with app-1 only, so app-2/3/4 must come from SQL;
+	// app-3 is deleted and is filtered out regardless of cache state.
+	appCache, err := cache.NewAppCache(10, time.Minute)
+	if err != nil {
+		t.Fatalf("NewAppCache returned error: %v", err)
+	}
+	appCache.BulkSet(map[string]db.App{"app-1": {ID: "app-1", Name: "Widget Tracker"}})
+
+	apps, err := store.ListApps(
+		context.Background(),
+		&db.AppFilter{AppIDs: []string{"app-1", "app-2", "app-3", "app-4"}},
+		db.SortBy{Field: db.SortByName},
+		db.WithCache(appCache),
+		db.WithLimit(2),
+		db.WithOffset(1),
+	)
+	if err != nil {
+		t.Fatalf("ListApps returned error: %v", err)
+	}
+
+	// Sorted by name: Gadget Dashboard(app-2), Jobless App(app-4), Widget
+	// Tracker(app-1) — offset 1, limit 2 over the full 3-row merged set
+	// should yield app-4 then app-1, not a double-paginated subset.
+	if len(apps) != 2 || apps[0].ID != "app-4" || apps[1].ID != "app-1" {
+		t.Fatalf("expected [app-4 app-1] from the merged+paged result, got %+v", apps)
+	}
+}
+
+// seedConformanceFixtures loads the same apps/users/workspace/category/job
+// rows as dbmem's newFixtureMemory, so storetest.Run's assertions hold for
+// both Store implementations.
+func seedConformanceFixtures(sqlDB *sql.DB) error {
+	statements := []string{
+		`TRUNCATE app_listing, users, workspace, app_category_group, app_category_app, jobs CASCADE`,
+		`INSERT INTO app_listing (id, name, description, integrator_username, integration_date, deleted, disabled) VALUES
+			('app-1', 'Widget Tracker', 'tracks widgets', 'alice', '2026-01-01', false, false),
+			('app-2', 'Gadget Dashboard', 'dashboards for gadgets', 'bob', '2026-06-01', false, false),
+			('app-3', 'Deleted App', NULL, 'bob', '2026-01-01', true, false),
+			('app-4', 'Jobless App', NULL, 'bob', '2026-01-01', false, false)`,
+		`INSERT INTO users (id, username) VALUES ('u-alice', 'alice'), ('u-bob', 'bob')`,
+		`INSERT INTO workspace (user_id, root_category_id) VALUES ('u-alice', 'root-1')`,
+		`INSERT INTO app_category_group (parent_category_id, child_category_id, child_index) VALUES ('root-1', 7, 0), ('root-2', 8, 0)`,
+		`INSERT INTO app_category_app (app_category_id, app_id) VALUES (7, 'app-1'), (8, 'app-1')`,
+		`INSERT INTO jobs (id, app_id, user_id, start_date) VALUES
+			('job-1', 'app-1', 'u-alice', now() - interval '1 hour'),
+			('job-2', 'app-1', 'u-alice', now() - interval '48 hours'),
+			('job-3', 'app-2', 'u-bob', now() - interval '30 days')`,
+	}
+	for _, stmt := range statements {
+		if _, err := sqlDB.Exec(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
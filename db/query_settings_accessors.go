@@ -0,0 +1,13 @@
+package db
+
+// Limit reports the configured row limit, if any. It lets Store
+// implementations outside this package (e.g. dbmem) honor WithLimit without
+// reaching into QuerySettings' private fields.
+func (s *QuerySettings) Limit() (hasLimit bool, limit int) {
+	return s.hasLimit, s.limit
+}
+
+// Offset reports the configured row offset, if any.
+func (s *QuerySettings) Offset() (hasOffset bool, offset int) {
+	return s.hasOffset, s.offset
+}
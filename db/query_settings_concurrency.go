@@ -0,0 +1,15 @@
+package db
+
+// WithConcurrencyLimit bounds how many dashboard sections AggregateDashboard
+// fetches at once. Without it, all sections run concurrently.
+func WithConcurrencyLimit(n int) QueryOption {
+	return func(s *QuerySettings) {
+		s.hasConcurrencyLimit = true
+		s.concurrencyLimit = n
+	}
+}
+
+// ConcurrencyLimit reports the configured concurrency limit, if any.
+func (s *QuerySettings) ConcurrencyLimit() (hasLimit bool, limit int) {
+	return s.hasConcurrencyLimit, s.concurrencyLimit
+}
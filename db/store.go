@@ -0,0 +1,16 @@
+package db
+
+import "context"
+
+// Store is the set of app-listing queries the rest of the codebase depends
+// on. *Database is the Postgres-backed implementation; dbmem.New provides an
+// in-memory fake for tests that don't want to stand up a real database.
+type Store interface {
+	ListApps(ctx context.Context, filter *AppFilter, sort SortBy, opts ...QueryOption) ([]App, error)
+	PopularFeaturedApps(ctx context.Context, cfg *AppsQueryConfig, opts ...QueryOption) ([]App, error)
+	PublicAppsQuery(ctx context.Context, username string, groupIndex int, publicAppIDs []string, opts ...QueryOption) ([]App, error)
+	RecentlyAddedApps(ctx context.Context, cfg *AppsQueryConfig, opts ...QueryOption) ([]App, error)
+	RecentlyUsedApps(ctx context.Context, cfg *AppsQueryConfig, opts ...QueryOption) ([]App, error)
+}
+
+var _ Store = (*Database)(nil)
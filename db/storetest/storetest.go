@@ -0,0 +1,128 @@
+// Package storetest holds a table-driven conformance suite that exercises
+// any db.Store implementation the same way, so dbmem and the real
+// Postgres-backed Database can be checked against identical cases.
+package storetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sboleyn/dashboard-aggregator/db"
+)
+
+// Seed names the fixture IDs the suite asserts against. Callers seed their
+// Store (in-memory slices, or rows in a real database) to match before
+// calling Run.
+type Seed struct {
+	// GadgetAppID is the only app whose name/description contains
+	// "gadget".
+	GadgetAppID string
+
+	// FavoritesUsername/FavoritesGroupIndex identify a user whose
+	// favorite category tree contains exactly FavoritedAppID.
+	FavoritesUsername   string
+	FavoritesGroupIndex int
+	FavoritedAppID      string
+
+	// JobRunnerUsername has run exactly one job, against JobRunnerAppID.
+	// At least one other seeded app must have zero jobs, to exercise the
+	// jobless-app-is-excluded behavior of RanByUsername.
+	JobRunnerUsername string
+	JobRunnerAppID    string
+
+	// RankedAppIDs names at least two seeded apps to exercise
+	// AppFilter.SearchRanking's narrow-and-reorder-by-score behavior.
+	RankedAppIDs []string
+
+	// MultiCategoryAppID belongs to both MultiCategoryIDs and has exactly
+	// MultiCategoryJobCount jobs, to exercise that filtering by CategoryIDs
+	// doesn't multiply the app (and its job aggregates) once per matching
+	// category row.
+	MultiCategoryAppID    string
+	MultiCategoryIDs      []int
+	MultiCategoryJobCount int
+}
+
+// Run exercises store against every case in the suite.
+func Run(t *testing.T, store db.Store, seed Seed) {
+	t.Helper()
+
+	t.Run("NameMatches", func(t *testing.T) {
+		apps, err := store.ListApps(context.Background(), &db.AppFilter{NameMatches: "gadget"}, db.SortBy{Field: db.SortByName})
+		if err != nil {
+			t.Fatalf("ListApps returned error: %v", err)
+		}
+		if len(apps) != 1 || apps[0].ID != seed.GadgetAppID {
+			t.Fatalf("expected only %s to match, got %+v", seed.GadgetAppID, apps)
+		}
+	})
+
+	t.Run("OnlyFavorites", func(t *testing.T) {
+		apps, err := store.ListApps(context.Background(), &db.AppFilter{
+			OnlyFavorites:       true,
+			FavoritesUsername:   seed.FavoritesUsername,
+			FavoritesGroupIndex: seed.FavoritesGroupIndex,
+		}, db.SortBy{Field: db.SortByName})
+		if err != nil {
+			t.Fatalf("ListApps returned error: %v", err)
+		}
+		if len(apps) != 1 || apps[0].ID != seed.FavoritedAppID || !apps[0].IsFavorite {
+			t.Fatalf("expected only %s as a favorite, got %+v", seed.FavoritedAppID, apps)
+		}
+	})
+
+	t.Run("RanByUsernameExcludesJoblessApps", func(t *testing.T) {
+		apps, err := store.ListApps(context.Background(), &db.AppFilter{RanByUsername: seed.JobRunnerUsername}, db.SortBy{Field: db.SortByName})
+		if err != nil {
+			t.Fatalf("ListApps returned error: %v", err)
+		}
+		if len(apps) != 1 || apps[0].ID != seed.JobRunnerAppID {
+			t.Fatalf("expected only the app %s ran, got %+v", seed.JobRunnerUsername, apps)
+		}
+	})
+
+	t.Run("CategoryIDsDedupesAppInMultipleCategories", func(t *testing.T) {
+		if len(seed.MultiCategoryIDs) < 2 {
+			t.Skip("seed.MultiCategoryIDs needs at least two category IDs")
+		}
+
+		apps, err := store.ListApps(context.Background(), &db.AppFilter{CategoryIDs: seed.MultiCategoryIDs}, db.SortBy{Field: db.SortByJobCount, Direction: db.Desc})
+		if err != nil {
+			t.Fatalf("ListApps returned error: %v", err)
+		}
+		if len(apps) != 1 || apps[0].ID != seed.MultiCategoryAppID {
+			t.Fatalf("expected only %s, got %+v", seed.MultiCategoryAppID, apps)
+		}
+		if apps[0].JobCount != seed.MultiCategoryJobCount {
+			t.Fatalf("expected job_count %d (not multiplied by matching %d categories), got %d", seed.MultiCategoryJobCount, len(seed.MultiCategoryIDs), apps[0].JobCount)
+		}
+	})
+
+	t.Run("SearchRankingNarrowsAndReordersByScore", func(t *testing.T) {
+		if len(seed.RankedAppIDs) < 2 {
+			t.Skip("seed.RankedAppIDs needs at least two app IDs")
+		}
+
+		ranking := make([]db.SearchRanking, len(seed.RankedAppIDs))
+		for i, id := range seed.RankedAppIDs {
+			// Reverse of seed order, so a correct implementation must
+			// actually reorder by score rather than leaving SortBy's order
+			// in place.
+			ranking[len(seed.RankedAppIDs)-1-i] = db.SearchRanking{AppID: id, Score: float64(i)}
+		}
+
+		apps, err := store.ListApps(context.Background(), &db.AppFilter{SearchRanking: ranking}, db.SortBy{Field: db.SortByName})
+		if err != nil {
+			t.Fatalf("ListApps returned error: %v", err)
+		}
+		if len(apps) != len(seed.RankedAppIDs) {
+			t.Fatalf("expected exactly the %d ranked apps, got %+v", len(seed.RankedAppIDs), apps)
+		}
+		for i, a := range apps {
+			want := seed.RankedAppIDs[len(seed.RankedAppIDs)-1-i]
+			if a.ID != want {
+				t.Fatalf("expected apps ordered by descending score %v, got %+v", seed.RankedAppIDs, apps)
+			}
+		}
+	})
+}
@@ -0,0 +1,137 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"go.opentelemetry.io/otel"
+)
+
+const otelName = "search"
+
+// searchFields lists the Document json field names (with optional ^boost
+// suffixes) that Search matches query text against. Keeping this as the one
+// place both Search and the json tags on Document are checked against
+// prevents the ES-side field names silently drifting from what IndexApp
+// actually writes.
+var searchFields = []string{"name^3", "description", "wiki_content", "integrator_username"}
+
+// ElasticSearcher is a Searcher backed by ElasticSearch.
+type ElasticSearcher struct {
+	client *elasticsearch.Client
+	index  string
+}
+
+// NewElasticSearcher connects to the ElasticSearch cluster at url (typically
+// sourced from an env var) and indexes apps into index.
+func NewElasticSearcher(url string, index string) (*ElasticSearcher, error) {
+	client, err := elasticsearch.NewClient(elasticsearch.Config{
+		Addresses: []string{url},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating elasticsearch client: %w", err)
+	}
+	return &ElasticSearcher{client: client, index: index}, nil
+}
+
+func (e *ElasticSearcher) IndexApp(ctx context.Context, doc Document) error {
+	ctx, span := otel.Tracer(otelName).Start(ctx, "ElasticSearcher.IndexApp")
+	defer span.End()
+
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("marshaling app document: %w", err)
+	}
+
+	resp, err := e.client.Index(
+		e.index,
+		bytes.NewReader(body),
+		e.client.Index.WithDocumentID(doc.AppID),
+		e.client.Index.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("indexing app %s: %w", doc.AppID, err)
+	}
+	defer resp.Body.Close()
+	if resp.IsError() {
+		return fmt.Errorf("indexing app %s: %s", doc.AppID, resp.Status())
+	}
+	return nil
+}
+
+func (e *ElasticSearcher) DeleteApp(ctx context.Context, appID string) error {
+	ctx, span := otel.Tracer(otelName).Start(ctx, "ElasticSearcher.DeleteApp")
+	defer span.End()
+
+	resp, err := e.client.Delete(
+		e.index,
+		appID,
+		e.client.Delete.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("deleting app %s: %w", appID, err)
+	}
+	defer resp.Body.Close()
+	if resp.IsError() && resp.StatusCode != 404 {
+		return fmt.Errorf("deleting app %s: %s", appID, resp.Status())
+	}
+	return nil
+}
+
+func (e *ElasticSearcher) Search(ctx context.Context, query SearchQuery) ([]AppHit, error) {
+	ctx, span := otel.Tracer(otelName).Start(ctx, "ElasticSearcher.Search")
+	defer span.End()
+
+	var buf bytes.Buffer
+	esQuery := map[string]interface{}{
+		"query": map[string]interface{}{
+			"multi_match": map[string]interface{}{
+				"query":  query.Text,
+				"fields": searchFields,
+			},
+		},
+		"from": query.Offset,
+	}
+	if query.Limit > 0 {
+		esQuery["size"] = query.Limit
+	}
+	if err := json.NewEncoder(&buf).Encode(esQuery); err != nil {
+		return nil, fmt.Errorf("encoding search query: %w", err)
+	}
+
+	resp, err := e.client.Search(
+		e.client.Search.WithContext(ctx),
+		e.client.Search.WithIndex(e.index),
+		e.client.Search.WithBody(&buf),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("searching apps: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.IsError() {
+		return nil, fmt.Errorf("searching apps: %s", resp.Status())
+	}
+
+	var result struct {
+		Hits struct {
+			Hits []struct {
+				ID    string  `json:"_id"`
+				Score float64 `json:"_score"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding search response: %w", err)
+	}
+
+	hits := make([]AppHit, 0, len(result.Hits.Hits))
+	for _, h := range result.Hits.Hits {
+		hits = append(hits, AppHit{AppID: h.ID, Score: h.Score})
+	}
+	return hits, nil
+}
+
+var _ Searcher = (*ElasticSearcher)(nil)
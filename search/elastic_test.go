@@ -0,0 +1,38 @@
+package search
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestDocumentJSONKeysMatchSearchFields guards against the json field names
+// IndexApp writes drifting from the field names Search's multi_match queries
+// in searchFields — ElasticSearch field names are case-sensitive, so a
+// mismatch silently returns zero hits.
+func TestDocumentJSONKeysMatchSearchFields(t *testing.T) {
+	doc := Document{
+		AppID:              "app-1",
+		Name:               "Widget Tracker",
+		Description:        "tracks widgets",
+		WikiContent:        "wiki body",
+		IntegratorUsername: "alice",
+	}
+
+	body, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("marshaling document: %v", err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		t.Fatalf("unmarshaling document: %v", err)
+	}
+
+	for _, searched := range searchFields {
+		name, _, _ := strings.Cut(searched, "^")
+		if _, ok := fields[name]; !ok {
+			t.Errorf("multi_match searches %q but Document marshals no such key; got %v", name, fields)
+		}
+	}
+}
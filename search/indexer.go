@@ -0,0 +1,94 @@
+package search
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/sboleyn/dashboard-aggregator/db"
+)
+
+// WikiFetcher fetches the rendered body of an app's wiki page, so Resync can
+// feed it to the Searcher as free-text content. Implementations wrap
+// whatever actually hosts the wiki (e.g. an HTTP client against a Confluence
+// export endpoint); there's no in-repo implementation here to avoid coupling
+// this package to that service.
+type WikiFetcher interface {
+	FetchContent(ctx context.Context, wikiURL string) (string, error)
+}
+
+// Indexer keeps a Searcher's index in sync with the app_listing table. It
+// replays every app once on startup and can be triggered again later (e.g.
+// from an admin endpoint or a cron) to re-sync after drift.
+type Indexer struct {
+	store       db.Store
+	searcher    Searcher
+	wikiFetcher WikiFetcher
+}
+
+// IndexerOption configures optional Indexer behavior.
+type IndexerOption func(*Indexer)
+
+// WithWikiFetcher makes Resync fetch and index each app's wiki content in
+// addition to its name and description. Without it, Resync indexes apps
+// with an empty WikiContent.
+func WithWikiFetcher(f WikiFetcher) IndexerOption {
+	return func(idx *Indexer) {
+		idx.wikiFetcher = f
+	}
+}
+
+// NewIndexer builds an Indexer that reads apps from store and writes them to
+// searcher.
+func NewIndexer(store db.Store, searcher Searcher, opts ...IndexerOption) *Indexer {
+	idx := &Indexer{store: store, searcher: searcher}
+	for _, opt := range opts {
+		opt(idx)
+	}
+	return idx
+}
+
+// Resync replays every listed app (not deleted or disabled, with an
+// integration date) from app_listing into the Searcher, overwriting each
+// one's indexed document. Documents of apps that are no longer listed are
+// not removed.
+func (idx *Indexer) Resync(ctx context.Context) error {
+	ctx, span := otel.Tracer(otelName).Start(ctx, "Indexer.Resync")
+	defer span.End()
+
+	apps, err := idx.store.ListApps(ctx, &db.AppFilter{}, db.SortBy{Field: db.SortByIntegrationDate})
+	if err != nil {
+		return err
+	}
+
+	for _, a := range apps {
+		doc := Document{
+			AppID:              a.ID,
+			Name:               a.Name,
+			Description:        a.Description,
+			IntegratorUsername: a.Username,
+		}
+		if idx.wikiFetcher != nil && a.WikiURL != "" {
+			content, err := idx.wikiFetcher.FetchContent(ctx, a.WikiURL)
+			if err != nil {
+				return err
+			}
+			doc.WikiContent = content
+		}
+		if err := idx.searcher.IndexApp(ctx, doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Start replays the full index once in the background. Callers that need to
+// know when the initial sync finishes, or want to retrigger it later,
+// should call Resync directly instead.
+func (idx *Indexer) Start(ctx context.Context, onError func(error)) {
+	go func() {
+		if err := idx.Resync(ctx); err != nil && onError != nil {
+			onError(err)
+		}
+	}()
+}
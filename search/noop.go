@@ -0,0 +1,17 @@
+package search
+
+import "context"
+
+// NoopSearcher is a Searcher that indexes nothing and finds nothing, for
+// deployments without an external search engine. They keep their existing
+// ILIKE-based behavior by never returning a ranking for db's search hooks to
+// act on.
+type NoopSearcher struct{}
+
+func (NoopSearcher) IndexApp(context.Context, Document) error { return nil }
+
+func (NoopSearcher) DeleteApp(context.Context, string) error { return nil }
+
+func (NoopSearcher) Search(context.Context, SearchQuery) ([]AppHit, error) { return nil, nil }
+
+var _ Searcher = NoopSearcher{}
@@ -0,0 +1,36 @@
+// Package search provides free-text app discovery backed by a pluggable
+// search engine, as an alternative to the ILIKE scans in the db package.
+package search
+
+import "context"
+
+// SearchQuery is a free-text lookup against indexed apps.
+type SearchQuery struct {
+	Text   string
+	Limit  int
+	Offset int
+}
+
+// AppHit is one app matched by a Searcher, along with its relevance score.
+type AppHit struct {
+	AppID string
+	Score float64
+}
+
+// Document is the subset of an app's fields a Searcher indexes for
+// free-text discovery.
+type Document struct {
+	AppID              string `json:"app_id"`
+	Name               string `json:"name"`
+	Description        string `json:"description"`
+	WikiContent        string `json:"wiki_content"`
+	IntegratorUsername string `json:"integrator_username"`
+}
+
+// Searcher indexes apps and serves free-text search over them. IndexApp and
+// DeleteApp keep the index in sync with app_listing; Search answers a query.
+type Searcher interface {
+	IndexApp(ctx context.Context, doc Document) error
+	DeleteApp(ctx context.Context, appID string) error
+	Search(ctx context.Context, query SearchQuery) ([]AppHit, error)
+}